@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/user/antrea-lfx-task/pkg/capture"
+	"github.com/user/antrea-lfx-task/pkg/controller"
+
+	nodecapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/nodecapture/v1alpha1"
+	versioned "github.com/user/antrea-lfx-task/pkg/client/clientset/versioned"
+	ncinformers "github.com/user/antrea-lfx-task/pkg/client/informers/externalversions"
+)
+
+// The agent binary is the per-Node DaemonSet: it reconciles only the
+// NodeCapture objects the central manager (cmd/manager) has dispatched to
+// this Node, actually running captures via a CaptureManager.
+func main() {
+	captureBackend := flag.String("capture-backend", capture.BackendTcpdump,
+		"Capture backend to use: tcpdump (exec nsenter+tcpdump) or afpacket (in-process AF_PACKET capture).")
+	flag.Parse()
+
+	nodeName := os.Getenv("NODE_NAME")
+	if nodeName == "" {
+		slog.Error("NODE_NAME environment variable not set")
+		os.Exit(1)
+	}
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		kubeconfig := os.Getenv("KUBECONFIG")
+		if kubeconfig == "" {
+			kubeconfig = os.Getenv("HOME") + "/.kube/config"
+		}
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		if err != nil {
+			slog.Error("Failed to build config", "err", err)
+			os.Exit(1)
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		slog.Error("Failed to create client", "err", err)
+		os.Exit(1)
+	}
+
+	ncClient, err := versioned.NewForConfig(config)
+	if err != nil {
+		slog.Error("Failed to create NodeCapture client", "err", err)
+		os.Exit(1)
+	}
+
+	// Informers, both scoped to this Node so the DaemonSet only watches what
+	// it can act on. Pods support a real field selector; NodeCapture is a
+	// CRD, so it's scoped via the NodeNameLabel instead.
+	podTweak := func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", nodeName).String()
+	}
+	podFactory := informers.NewSharedInformerFactoryWithOptions(
+		clientset,
+		0,
+		informers.WithTweakListOptions(podTweak),
+	)
+	podInformer := podFactory.Core().V1().Pods().Informer()
+
+	ncTweak := func(options *metav1.ListOptions) {
+		options.LabelSelector = fmt.Sprintf("%s=%s", nodecapturev1alpha1.NodeNameLabel, nodeName)
+	}
+	ncFactory := ncinformers.NewFilteredSharedInformerFactory(ncClient, 0, "", ncTweak)
+	ncInformer := ncFactory.Nodecapture().V1alpha1().NodeCaptures().Informer()
+
+	ctrl := controller.NewController(ncClient, podInformer, ncInformer, *captureBackend)
+	stopCh := make(chan struct{})
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		close(stopCh)
+	}()
+
+	ctrl.Run(stopCh)
+}
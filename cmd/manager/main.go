@@ -1,29 +1,43 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/workqueue"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 
-	"github.com/user/antrea-lfx-task/pkg/controller"
+	"github.com/user/antrea-lfx-task/pkg/centralcontroller"
+
+	versioned "github.com/user/antrea-lfx-task/pkg/client/clientset/versioned"
+	informersexternal "github.com/user/antrea-lfx-task/pkg/client/informers/externalversions"
 )
 
+const leaseLockName = "antrea-packetcapture-manager"
+
+// The manager binary is the cluster-wide, leader-elected Deployment: it
+// watches PacketCapture objects across every namespace, resolves their
+// target selectors against cluster-wide Pods, and dispatches/aggregates the
+// per-Pod NodeCapture objects that cmd/agent instances actually act on.
 func main() {
-	// 1. Config
-	nodeName := os.Getenv("NODE_NAME")
-	if nodeName == "" {
-		slog.Error("NODE_NAME environment variable not set")
-		os.Exit(1)
+	podName := os.Getenv("POD_NAME")
+	if podName == "" {
+		podName = string(uuid.NewUUID())
+	}
+	leaseNamespace := os.Getenv("POD_NAMESPACE")
+	if leaseNamespace == "" {
+		leaseNamespace = "kube-system"
 	}
 
 	config, err := rest.InClusterConfig()
@@ -45,53 +59,70 @@ func main() {
 		os.Exit(1)
 	}
 
-	// 2. Informer with Node Selector Optimization
-	// This creates a watch specifically for Pods on this Node.
-	tweakListOptions := func(options *metav1.ListOptions) {
-		options.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", nodeName).String()
+	pcClient, err := versioned.NewForConfig(config)
+	if err != nil {
+		slog.Error("Failed to create PacketCapture client", "err", err)
+		os.Exit(1)
 	}
 
-	factory := informers.NewSharedInformerFactoryWithOptions(
-		clientset,
-		0,
-		informers.WithTweakListOptions(tweakListOptions),
-	)
-
-	informer := factory.Core().V1().Pods().Informer()
-	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
-
-	// 3. Event Handlers
-	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			key, err := cache.MetaNamespaceKeyFunc(obj)
-			if err == nil {
-				queue.Add(key)
-			}
-		},
-		UpdateFunc: func(oldObj, newObj interface{}) {
-			key, err := cache.MetaNamespaceKeyFunc(newObj)
-			if err == nil {
-				queue.Add(key)
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-			if err == nil {
-				queue.Add(key)
-			}
-		},
-	})
+	podFactory := informers.NewSharedInformerFactory(clientset, 0)
+	podInformer := podFactory.Core().V1().Pods().Informer()
+
+	pcFactory := informersexternal.NewSharedInformerFactory(pcClient, 0)
+	pcInformer := pcFactory.Packetcapture().V1alpha1().PacketCaptures().Informer()
+	ncInformer := pcFactory.Nodecapture().V1alpha1().NodeCaptures().Informer()
+
+	ctrl := centralcontroller.NewController(pcClient, podInformer, pcInformer, ncInformer)
 
-	// 4. Start
-	ctrl := controller.NewController(queue, informer.GetIndexer(), informer)
 	stopCh := make(chan struct{})
-	
+	var stopOnce sync.Once
+	stop := func() { stopOnce.Do(func() { close(stopCh) }) }
+
+	// leaderelection.RunOrDie only releases the lease (ReleaseOnCancel) and
+	// returns once its context is cancelled; stopCh alone only stops
+	// ctrl.Run, so a signal has to cancel this ctx too or the process idles
+	// holding leadership until the kubelet SIGKILLs it.
+	ctx, cancel := context.WithCancel(context.Background())
+
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-sigCh
-		close(stopCh)
+		stop()
+		cancel()
 	}()
 
-	ctrl.Run(stopCh)
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseLockName,
+			Namespace: leaseNamespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: podName,
+		},
+	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				slog.Info("Became leader, starting central controller", "identity", podName)
+				ctrl.Run(stopCh)
+			},
+			OnStoppedLeading: func() {
+				slog.Info("Lost leadership, stopping", "identity", podName)
+				stop()
+			},
+			OnNewLeader: func(identity string) {
+				if identity != podName {
+					slog.Info("New leader elected", "identity", identity)
+				}
+			},
+		},
+	})
 }
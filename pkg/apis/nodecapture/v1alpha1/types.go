@@ -0,0 +1,113 @@
+// Package v1alpha1 contains the NodeCapture API types.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	packetcapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/packetcapture/v1alpha1"
+)
+
+// Finalizer is set on NodeCapture objects so the node-local agent can flush
+// in-flight capture buffers and finish writing pcap files before the object
+// disappears.
+const Finalizer = "nodecapture.antrea.io/capture-finalizer"
+
+// NodeNameLabel is set by the central controller on every NodeCapture it
+// creates, naming the Node the capture should run on. The node-local agent
+// uses it to filter the NodeCaptures it watches, across all namespaces,
+// down to its own Node, since NodeCapture as a CRD has no field selector
+// support on spec.nodeName.
+const NodeNameLabel = "nodecapture.antrea.io/node-name"
+
+// OwnerLabel is set by the central controller on every NodeCapture it
+// creates, naming the owning PacketCapture by its name alone; the
+// NodeCapture lives in the PacketCapture's namespace. It complements the
+// object's OwnerReference so the central controller can pick out a
+// PacketCapture's NodeCaptures from its cache by label.
+const OwnerLabel = "nodecapture.antrea.io/owner"
+
+// NodeCapturePhase describes the current state of a capture on a single Node.
+type NodeCapturePhase string
+
+const (
+	NodeCapturePhasePending   NodeCapturePhase = "Pending"
+	NodeCapturePhaseRunning   NodeCapturePhase = "Running"
+	NodeCapturePhaseCompleted NodeCapturePhase = "Completed"
+	NodeCapturePhaseFailed    NodeCapturePhase = "Failed"
+)
+
+// NodeCaptureSpec describes a single-Pod capture dispatched by the central
+// controller to one Node. It carries everything the node-local agent needs
+// to run the capture without re-resolving the parent PacketCapture's
+// selector.
+type NodeCaptureSpec struct {
+	// NodeName is the Node the agent reconciling this object must run on.
+	NodeName string `json:"nodeName"`
+	// PodNamespace and PodName identify the single Pod to capture traffic
+	// from.
+	PodNamespace string `json:"podNamespace"`
+	PodName      string `json:"podName"`
+	// Interface is the network interface inside the Pod's netns to capture
+	// on. Defaults to "any" when empty.
+	// +optional
+	Interface string `json:"interface,omitempty"`
+	// Filter is a BPF filter expression applied to the capture.
+	// +optional
+	Filter string `json:"filter,omitempty"`
+	// FileCount is the number of most recent rotated pcap filenames kept in
+	// Status.Files. Chunks already delivered to the Destination are not
+	// deleted.
+	// +optional
+	FileCount int32 `json:"fileCount,omitempty"`
+	// FileSizeMB is the size, in megabytes, at which a pcap file is rotated.
+	// +optional
+	FileSizeMB int32 `json:"fileSizeMB,omitempty"`
+	// Duration bounds the total lifetime of the capture. A nil Duration runs
+	// until the NodeCapture object is deleted.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Destination configures where rotated pcap files are delivered.
+	Destination packetcapturev1alpha1.PacketCaptureDestination `json:"destination"`
+}
+
+// NodeCaptureStatus reports the observed state of a capture on a single Node.
+type NodeCaptureStatus struct {
+	Phase NodeCapturePhase `json:"phase,omitempty"`
+	// Reason carries a human-readable explanation when Phase is Failed.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// StartTime is when the capture transitioned to Running.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// BytesCaptured is the cumulative number of bytes written across all
+	// rotated files.
+	// +optional
+	BytesCaptured int64 `json:"bytesCaptured,omitempty"`
+	// Files lists the most recent FileCount rotated pcap filenames, oldest
+	// first.
+	// +optional
+	Files []string `json:"files,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeCapture is the Schema for the nodecaptures API. It is dispatched by the
+// central controller for each Pod a PacketCapture's selector resolves to, and
+// reconciled by the node-local agent on the Node named in its Spec.
+type NodeCapture struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeCaptureSpec   `json:"spec,omitempty"`
+	Status NodeCaptureStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeCaptureList is a list of NodeCapture resources.
+type NodeCaptureList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NodeCapture `json:"items"`
+}
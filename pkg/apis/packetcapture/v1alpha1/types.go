@@ -0,0 +1,152 @@
+// Package v1alpha1 contains the PacketCapture API types.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CaptureFinalizer is set on PacketCapture objects by the central
+// controller, which removes it only once every NodeCapture the
+// PacketCapture owns has finished deleting (each NodeCapture's own
+// finalizer lets its node-local agent flush the capture first).
+const CaptureFinalizer = "tcpdump.antrea.io/capture-finalizer"
+
+// PacketCapturePhase describes the current state of a capture.
+type PacketCapturePhase string
+
+const (
+	PacketCapturePhasePending   PacketCapturePhase = "Pending"
+	PacketCapturePhaseRunning   PacketCapturePhase = "Running"
+	PacketCapturePhaseCompleted PacketCapturePhase = "Completed"
+	PacketCapturePhaseFailed    PacketCapturePhase = "Failed"
+)
+
+// PacketCaptureTargetSelector selects the Pod(s) a capture runs against.
+// Exactly one of PodName or PodSelector should be set; Namespace scopes both.
+type PacketCaptureTargetSelector struct {
+	Namespace string `json:"namespace,omitempty"`
+	// PodName targets a single, specific Pod.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+	// PodSelector targets every Pod matching the label selector within Namespace.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// PacketCaptureDestination configures where rotated pcap files are delivered.
+// Exactly one field should be set.
+type PacketCaptureDestination struct {
+	// LocalDir writes rotated files to a directory on the capturing Node.
+	// +optional
+	LocalDir *LocalDirDestination `json:"localDir,omitempty"`
+	// S3 uploads rotated files to an S3-compatible bucket.
+	// +optional
+	S3 *S3Destination `json:"s3,omitempty"`
+	// Syslog forwards capture events to a syslog endpoint.
+	// +optional
+	Syslog *SyslogDestination `json:"syslog,omitempty"`
+	// Kafka streams rotated pcap chunks to a Kafka topic for analytics
+	// pipelines.
+	// +optional
+	Kafka *KafkaDestination `json:"kafka,omitempty"`
+	// GRPC streams rotated pcap chunks to a central collector over gRPC.
+	// +optional
+	GRPC *GRPCDestination `json:"grpc,omitempty"`
+}
+
+type LocalDirDestination struct {
+	Path string `json:"path"`
+}
+
+type S3Destination struct {
+	Bucket          string `json:"bucket"`
+	Region          string `json:"region,omitempty"`
+	Prefix          string `json:"prefix,omitempty"`
+	CredentialsName string `json:"credentialsName,omitempty"`
+}
+
+type SyslogDestination struct {
+	Address  string `json:"address"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+type KafkaDestination struct {
+	Brokers []string `json:"brokers"`
+	Topic   string   `json:"topic"`
+}
+
+type GRPCDestination struct {
+	// Address is the "host:port" of the collector's gRPC endpoint.
+	Address string `json:"address"`
+	// TLSSecretName, when set, names a Secret providing client TLS
+	// credentials for the collector connection.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// PacketCaptureSpec describes the capture a user wants run.
+type PacketCaptureSpec struct {
+	// Target selects which Pod(s) to capture traffic from.
+	Target PacketCaptureTargetSelector `json:"target"`
+	// Interface is the network interface inside the Pod's netns to capture on.
+	// Defaults to "any" when empty.
+	// +optional
+	Interface string `json:"interface,omitempty"`
+	// Filter is a BPF filter expression applied to the capture.
+	// +optional
+	Filter string `json:"filter,omitempty"`
+	// FileCount is the number of most recent rotated pcap filenames each
+	// Node's capture keeps in its status. Chunks already delivered to the
+	// Destination are not deleted.
+	// +optional
+	FileCount int32 `json:"fileCount,omitempty"`
+	// FileSizeMB is the size, in megabytes, at which a pcap file is rotated.
+	// +optional
+	FileSizeMB int32 `json:"fileSizeMB,omitempty"`
+	// Duration bounds the total lifetime of the capture. A nil Duration runs
+	// until the PacketCapture object is deleted.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Destination configures where rotated pcap files are delivered.
+	Destination PacketCaptureDestination `json:"destination"`
+}
+
+// PacketCaptureStatus reports the observed state of a capture.
+type PacketCaptureStatus struct {
+	Phase PacketCapturePhase `json:"phase,omitempty"`
+	// Reason carries a human-readable explanation when Phase is Failed.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// StartTime is when the capture transitioned to Running.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// BytesCaptured is the cumulative number of bytes written across all
+	// rotated files.
+	// +optional
+	BytesCaptured int64 `json:"bytesCaptured,omitempty"`
+	// Files lists the rotated pcap filenames reported by each of the
+	// capture's NodeCaptures, one NodeCapture's files after another.
+	// +optional
+	Files []string `json:"files,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PacketCapture is the Schema for the packetcaptures API.
+type PacketCapture struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PacketCaptureSpec   `json:"spec,omitempty"`
+	Status PacketCaptureStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PacketCaptureList is a list of PacketCapture resources.
+type PacketCaptureList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PacketCapture `json:"items"`
+}
@@ -0,0 +1,178 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	goruntime "runtime"
+
+	"github.com/google/gopacket/afpacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vishvananda/netns"
+	"golang.org/x/net/bpf"
+
+	nodecapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/nodecapture/v1alpha1"
+)
+
+var (
+	capturedPackets = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "antrea_packetcapture_afpacket_packets_total",
+		Help: "Number of packets captured by the afpacket backend, by interface.",
+	}, []string{"interface"})
+	capturedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "antrea_packetcapture_afpacket_bytes_total",
+		Help: "Number of bytes captured by the afpacket backend, by interface.",
+	}, []string{"interface"})
+)
+
+func init() {
+	prometheus.MustRegister(capturedPackets, capturedBytes)
+}
+
+// runAFPacket captures packets in-process over an AF_PACKET socket opened
+// inside the target Pod's network namespace, writing them as pcapng to the
+// configured Sink. Unlike runTcpdump it needs neither hostPID nor a tcpdump
+// binary, and it reacts to ctx cancellation immediately rather than waiting
+// on a signaled child process.
+func (cm *CaptureManager) runAFPacket(ctx context.Context, nc *nodecapturev1alpha1.NodeCapture, pid int, key string, state *captureState) {
+	defer close(state.done)
+
+	sink, err := SinkForDestination(nc.Spec.Destination)
+	if err != nil {
+		cm.failCapture(key, fmt.Sprintf("failed to set up capture destination: %v", err))
+		return
+	}
+	defer func() { logSinkCloseErr(key, sink.Close()) }()
+
+	iface := nc.Spec.Interface
+	if iface == "" {
+		iface = defaultInterface
+	}
+	fileSizeMB := nc.Spec.FileSizeMB
+	if fileSizeMB <= 0 {
+		fileSizeMB = defaultFileSizeMB
+	}
+	fileCount := nc.Spec.FileCount
+	if fileCount <= 0 {
+		fileCount = defaultFileCount
+	}
+
+	runCtx := ctx
+	if nc.Spec.Duration != nil {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, nc.Spec.Duration.Duration)
+		defer cancel()
+	}
+
+	// Every rotated chunk gets its own pcapng writer (Section Header Block
+	// included), so each one dispatched to the Sink is valid on its own
+	// instead of a mid-stream fragment of one continuous capture.
+	baseName := fmt.Sprintf("capture-%s-%s", nc.Namespace, nc.Name)
+	chunker := newSinkChunker(runCtx, cm, sink, key, baseName, "pcapng", newPcapngChunkWriter, int64(fileSizeMB)*1024*1024, fileCount)
+
+	slog.Info("Starting afpacket capture", "nodecapture", key, "pid", pid, "interface", iface)
+	captureErr := captureIntoChunker(runCtx, pid, iface, nc.Spec.Filter, chunker)
+	if err := chunker.Close(); err != nil {
+		slog.Error("Failed to flush final capture chunk to sink", "nodecapture", key, "err", err)
+	}
+
+	phase := nodecapturev1alpha1.NodeCapturePhaseCompleted
+	reason := ""
+	if captureErr != nil && runCtx.Err() == nil {
+		phase = nodecapturev1alpha1.NodeCapturePhaseFailed
+		reason = captureErr.Error()
+		slog.Error("afpacket capture exited with error", "nodecapture", key, "err", captureErr)
+	} else {
+		slog.Info("Capture finished", "nodecapture", key)
+	}
+
+	cm.mu.Lock()
+	state.status.Phase = phase
+	state.status.Reason = reason
+	cm.mu.Unlock()
+}
+
+// captureIntoChunker enters the netns of pid, opens an AF_PACKET socket on
+// iface with filter compiled down to a classic BPF program, and hands every
+// captured frame to chunker until ctx is done or the socket errors. It
+// returns nil on a clean ctx-triggered stop.
+func captureIntoChunker(ctx context.Context, pid int, iface, filter string, chunker *sinkChunker) error {
+	goruntime.LockOSThread()
+	defer goruntime.UnlockOSThread()
+
+	origNs, err := netns.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get current netns: %w", err)
+	}
+	defer origNs.Close()
+
+	targetNs, err := netns.GetFromPid(pid)
+	if err != nil {
+		return fmt.Errorf("failed to get netns for pid %d: %w", pid, err)
+	}
+	defer targetNs.Close()
+
+	if err := netns.Set(targetNs); err != nil {
+		return fmt.Errorf("failed to enter netns for pid %d: %w", pid, err)
+	}
+	defer netns.Set(origNs)
+
+	handle, err := afpacket.NewTPacket(afpacket.OptInterface(iface))
+	if err != nil {
+		return fmt.Errorf("failed to open AF_PACKET socket on %s: %w", iface, err)
+	}
+	defer handle.Close()
+
+	if filter != "" {
+		instructions, err := pcap.CompileBPFFilter(layers.LinkTypeEthernet, 65536, filter)
+		if err != nil {
+			return fmt.Errorf("failed to compile BPF filter %q: %w", filter, err)
+		}
+		rawInsns := make([]bpf.RawInstruction, len(instructions))
+		for i, ins := range instructions {
+			rawInsns[i] = bpf.RawInstruction{Op: ins.Code, Jt: ins.Jt, Jf: ins.Jf, K: ins.K}
+		}
+		if err := handle.SetBPF(rawInsns); err != nil {
+			return fmt.Errorf("failed to install BPF filter: %w", err)
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			handle.Close()
+		case <-stopped:
+		}
+	}()
+	defer close(stopped)
+
+	for {
+		data, ci, err := handle.ZeroCopyReadPacketData()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read packet: %w", err)
+		}
+		if err := chunker.WritePacket(ci, data); err != nil {
+			return fmt.Errorf("failed to write packet: %w", err)
+		}
+		capturedPackets.WithLabelValues(iface).Inc()
+		capturedBytes.WithLabelValues(iface).Add(float64(len(data)))
+	}
+}
+
+// newPcapngChunkWriter starts a pcapng writer, Section Header Block
+// included, over buf. Used by the afpacket backend.
+func newPcapngChunkWriter(buf *bytes.Buffer) (chunkPacketWriter, error) {
+	w, err := pcapgo.NewNgWriter(buf, layers.LinkTypeEthernet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pcapng writer: %w", err)
+	}
+	return w, nil
+}
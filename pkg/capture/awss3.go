@@ -0,0 +1,37 @@
+package capture
+
+import (
+	"context"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// awsS3Client adapts the AWS SDK's S3 client to the narrow s3Uploader
+// interface S3Sink depends on.
+type awsS3Client struct {
+	client *s3.Client
+}
+
+func newAWSS3Client(region, credentialsSecretName string) (s3Uploader, error) {
+	// credentialsSecretName names a Secret mounted onto the DaemonSet (e.g.
+	// as AWS_SHARED_CREDENTIALS_FILE); the SDK picks it up via the default
+	// credential chain, so there's nothing further to wire up here.
+	_ = credentialsSecretName
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return &awsS3Client{client: s3.NewFromConfig(cfg)}, nil
+}
+
+func (c *awsS3Client) PutObject(ctx context.Context, bucket, key string, body io.Reader) error {
+	_, err := c.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+	return err
+}
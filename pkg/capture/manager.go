@@ -1,213 +1,519 @@
 package capture
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodecapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/nodecapture/v1alpha1"
 )
 
 const (
-	AnnotationKey = "tcpdump.antrea.io"
-	CaptureDir    = "/var/log/antrea-captures"
+	// CaptureDir is the directory used when a NodeCapture's Destination has
+	// no field set at all.
+	CaptureDir = "/var/log/antrea-captures"
+
+	defaultInterface  = "any"
+	defaultFileCount  = 1
+	defaultFileSizeMB = 1
+	// maxSnaplen matches tcpdump's own default snapshot length, so the pcap
+	// header written for each tcpdump-backend chunk never claims less than
+	// tcpdump captured.
+	maxSnaplen = 262144
+
+	// BackendTcpdump execs nsenter+tcpdump into the target Pod's netns, the
+	// original capture path. It requires hostPID and a tcpdump binary in the
+	// image.
+	BackendTcpdump = "tcpdump"
+	// BackendAFPacket captures in-process over an AF_PACKET socket opened in
+	// the target Pod's netns, with no exec'd dependencies.
+	BackendAFPacket = "afpacket"
 )
 
+// captureState tracks the in-progress capture for a single NodeCapture.
+type captureState struct {
+	cancel        context.CancelFunc
+	cmd           *exec.Cmd
+	stopRequested bool
+	// done is closed once the capture backend has exited and finished
+	// writing final status, i.e. once it is safe to remove the capture's
+	// finalizer.
+	done   chan struct{}
+	status nodecapturev1alpha1.NodeCaptureStatus
+}
+
+// CaptureManager starts and stops captures on behalf of NodeCapture objects
+// and tracks their reported status in memory. Keyed by the NodeCapture's
+// namespace/name.
 type CaptureManager struct {
 	mu       sync.Mutex
-	captures map[string]context.CancelFunc // Key: namespace/name
+	captures map[string]*captureState
+	resolver PidResolver
+	backend  string
 }
 
-func NewCaptureManager() *CaptureManager {
+// NewCaptureManager builds a CaptureManager that runs captures through the
+// given backend (BackendTcpdump or BackendAFPacket). An unrecognized backend
+// falls back to BackendTcpdump.
+func NewCaptureManager(backend string) *CaptureManager {
 	if err := os.MkdirAll(CaptureDir, 0755); err != nil {
-		slog.Error("Failed to create capture directory", "err", err)
+		slog.Error("Failed to create default capture directory", "err", err)
+	}
+	if backend != BackendTcpdump && backend != BackendAFPacket {
+		slog.Warn("Unknown capture backend, defaulting to tcpdump", "backend", backend)
+		backend = BackendTcpdump
 	}
 	return &CaptureManager{
-		captures: make(map[string]context.CancelFunc),
+		captures: make(map[string]*captureState),
+		resolver: newDefaultPidResolver(),
+		backend:  backend,
 	}
 }
 
-func (cm *CaptureManager) SyncCapture(pod *corev1.Pod) error {
-	cm.mu.Lock()
-	defer cm.mu.Unlock()
+// newDefaultPidResolver prefers asking the node's CRI runtime for a
+// container's PID, falling back to a /proc cgroup scan when no runtime
+// socket is configured or reachable.
+func newDefaultPidResolver() PidResolver {
+	endpoint := os.Getenv("CRI_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "unix:///run/containerd/containerd.sock"
+	}
+	cri, err := NewCRIPidResolver(endpoint)
+	if err != nil {
+		slog.Warn("Failed to set up CRI pid resolver, falling back to /proc scanning only", "endpoint", endpoint, "err", err)
+		return ProcPidResolver{}
+	}
+	return &CompositeResolver{Primary: cri, Fallback: ProcPidResolver{}}
+}
 
-	key := pod.Namespace + "/" + pod.Name
-	val, hasAnnotation := pod.Annotations[AnnotationKey]
-	_, isRunning := cm.captures[key]
+// SyncCapture reconciles the desired NodeCapture against the running
+// captures this manager knows about, starting one if needed, and returns the
+// status that should be written back to the NodeCapture's status
+// subresource. pod is the resolved capture target; it is nil if no matching
+// Pod currently exists. SyncCapture never stops a capture — that is the job
+// of FinalizeCapture, invoked once the NodeCapture starts deleting.
+func (cm *CaptureManager) SyncCapture(nc *nodecapturev1alpha1.NodeCapture, pod *corev1.Pod) (*nodecapturev1alpha1.NodeCaptureStatus, error) {
+	key := nc.Namespace + "/" + nc.Name
 
-	// 1. Start if annotation present and not running
-	if hasAnnotation && !isRunning {
-		slog.Info("Starting capture", "pod", pod.Name, "limit", val)
-		ctx, cancel := context.WithCancel(context.Background())
-		cm.captures[key] = cancel
-		go cm.runTcpdump(ctx, pod, val, key)
+	cm.mu.Lock()
+	if state, isRunning := cm.captures[key]; isRunning {
+		status := state.status
+		cm.mu.Unlock()
+		return &status, nil
 	}
+	cm.mu.Unlock()
 
-	// 2. Stop if annotation removed and is running
-	if !hasAnnotation && isRunning {
-		slog.Info("Stopping capture (annotation removed)", "pod", pod.Name)
-		cm.stop(key, pod.Name)
+	if pod == nil || !isPodReady(pod) {
+		return &nodecapturev1alpha1.NodeCaptureStatus{Phase: nodecapturev1alpha1.NodeCapturePhasePending}, nil
 	}
 
-	// 3. Stop if Pod is deleting
-	if pod.DeletionTimestamp != nil && isRunning {
-		slog.Info("Stopping capture (pod terminating)", "pod", pod.Name)
-		cm.stop(key, pod.Name)
+	containerID, err := containerIDFromPod(pod)
+	if err != nil {
+		// Not something a retry will fix.
+		return &nodecapturev1alpha1.NodeCaptureStatus{
+			Phase:  nodecapturev1alpha1.NodeCapturePhaseFailed,
+			Reason: err.Error(),
+		}, nil
 	}
 
-	return nil
-}
+	// The container's cgroup entry (and the CRI runtime's record of it) can
+	// lag briefly behind the Pod turning Ready. Rather than blocking this
+	// call with an in-process retry loop -- which would stall reconciliation
+	// of every other NodeCapture behind the single worker processing this
+	// key -- try once and let the caller re-enqueue through the workqueue's
+	// own rate limiter on failure.
+	pid, err := cm.resolver.Resolve(context.Background(), containerID)
+	if err != nil {
+		return nil, fmt.Errorf("resolving pid for pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
 
-func (cm *CaptureManager) StopCaptureByKey(key string) {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
-	// Extract pod name from key for file cleanup (namespace/name)
-	parts := strings.Split(key, "/")
-	podName := key
-	if len(parts) > 1 {
-		podName = parts[1]
+	if state, isRunning := cm.captures[key]; isRunning {
+		// Lost the race with another reconcile; report what's running.
+		status := state.status
+		return &status, nil
 	}
-	cm.stop(key, podName)
-}
 
-func (cm *CaptureManager) stop(key string, podName string) {
-	if cancel, ok := cm.captures[key]; ok {
-		cancel()
-		delete(cm.captures, key)
-		cm.cleanupFiles(podName)
+	slog.Info("Starting capture", "nodecapture", key, "pod", pod.Name, "pid", pid)
+	ctx, cancel := context.WithCancel(context.Background())
+	now := metav1.Now()
+	state := &captureState{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		status: nodecapturev1alpha1.NodeCaptureStatus{
+			Phase:     nodecapturev1alpha1.NodeCapturePhaseRunning,
+			StartTime: &now,
+		},
 	}
+	cm.captures[key] = state
+	go cm.dispatchCapture(ctx, nc, pid, key, state)
+
+	status := state.status
+	return &status, nil
 }
 
-func (cm *CaptureManager) cleanupFiles(podName string) {
-	// Pattern: capture-<PodName>.pcap*
-	pattern := filepath.Join(CaptureDir, fmt.Sprintf("capture-%s.pcap*", podName))
-	matches, err := filepath.Glob(pattern)
-	if err != nil {
-		slog.Error("Failed to glob cleanup files", "err", err)
-		return
-	}
-	for _, f := range matches {
-		if err := os.Remove(f); err != nil {
-			slog.Error("Failed to remove capture file", "file", f, "err", err)
-		} else {
-			slog.Info("Removed capture file", "file", f)
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
 		}
 	}
+	return false
 }
 
-func (cm *CaptureManager) runTcpdump(ctx context.Context, pod *corev1.Pod, limit string, key string) {
-	// 1. Get ContainerID
+func containerIDFromPod(pod *corev1.Pod) (string, error) {
 	if len(pod.Status.ContainerStatuses) == 0 {
-		slog.Error("No container statuses found", "pod", pod.Name)
-		cm.mu.Lock()
-		delete(cm.captures, key)
-		cm.mu.Unlock()
-		return
+		return "", fmt.Errorf("pod %s/%s has no container statuses", pod.Namespace, pod.Name)
 	}
-	// Use the first container for simplicity, or find the main one
 	containerID := pod.Status.ContainerStatuses[0].ContainerID
-	// Format: containerd://<id>
 	parts := strings.Split(containerID, "://")
 	if len(parts) < 2 {
-		slog.Error("Invalid container ID format", "id", containerID)
-		return
+		return "", fmt.Errorf("invalid container ID format: %s", containerID)
 	}
-	cid := parts[1]
+	return parts[1], nil
+}
 
-	// 2. Find PID
-	pid, err := findPidByContainerID(cid)
-	if err != nil {
-		slog.Error("Failed to find PID for container", "id", cid, "err", err)
-		// Don't retry immediately in this simple loop, just exit.
-		// logic could be improved to retry.
+// FinalizeCapture requests a graceful shutdown of the capture for nc, if one
+// is running, and reports whether cleanup (stopping the backend so it
+// flushes its buffers, then writing final status) has completed. The caller
+// should keep the finalizer in place and retry until done is true.
+func (cm *CaptureManager) FinalizeCapture(nc *nodecapturev1alpha1.NodeCapture) (done bool, status *nodecapturev1alpha1.NodeCaptureStatus) {
+	key := nc.Namespace + "/" + nc.Name
+
+	cm.mu.Lock()
+	state, isRunning := cm.captures[key]
+	if !isRunning {
+		cm.mu.Unlock()
+		return true, nil
+	}
+
+	if !state.stopRequested {
+		state.stopRequested = true
+		slog.Info("Stopping capture gracefully (NodeCapture deleting)", "nodecapture", key)
+		if state.cmd != nil && state.cmd.Process != nil {
+			if err := state.cmd.Process.Signal(syscall.SIGINT); err != nil {
+				slog.Warn("Failed to signal tcpdump, cancelling instead", "nodecapture", key, "err", err)
+				state.cancel()
+			}
+		} else {
+			// Capture hasn't reached the point of exec'ing tcpdump yet, or is
+			// running the afpacket backend, which stops on ctx cancellation.
+			state.cancel()
+		}
+	}
+	cm.mu.Unlock()
+
+	select {
+	case <-state.done:
 		cm.mu.Lock()
+		defer cm.mu.Unlock()
 		delete(cm.captures, key)
-		cm.mu.Unlock()
+		finalStatus := state.status
+		return true, &finalStatus
+	default:
+		return false, nil
+	}
+}
+
+// StopCaptureByKey force-stops a capture, e.g. when its NodeCapture object
+// has been removed from the informer cache without going through deletion
+// finalization (the informer's DeleteFunc fires on a hard cache eviction too).
+func (cm *CaptureManager) StopCaptureByKey(key string) {
+	cm.mu.Lock()
+	state, ok := cm.captures[key]
+	if ok {
+		delete(cm.captures, key)
+	}
+	cm.mu.Unlock()
+	if ok {
+		state.cancel()
+	}
+}
+
+// dispatchCapture runs the capture through whichever backend this manager
+// was configured with.
+func (cm *CaptureManager) dispatchCapture(ctx context.Context, nc *nodecapturev1alpha1.NodeCapture, pid int, key string, state *captureState) {
+	if cm.backend == BackendAFPacket {
+		cm.runAFPacket(ctx, nc, pid, key, state)
 		return
 	}
+	cm.runTcpdump(ctx, nc, pid, key, state)
+}
 
-	// 3. Build Command
-	// tcpdump -C 1M -W <N> -w /var/log/antrea-captures/capture-<PodName>.pcap
-	pcapFile := filepath.Join(CaptureDir, fmt.Sprintf("capture-%s.pcap", pod.Name))
+func (cm *CaptureManager) runTcpdump(ctx context.Context, nc *nodecapturev1alpha1.NodeCapture, pid int, key string, state *captureState) {
+	defer close(state.done)
+
+	sink, err := SinkForDestination(nc.Spec.Destination)
+	if err != nil {
+		cm.failCapture(key, fmt.Sprintf("failed to set up capture destination: %v", err))
+		return
+	}
+	defer func() { logSinkCloseErr(key, sink.Close()) }()
 
-	// args: -t <PID> -n -- tcpdump ...
-	args := []string{
+	iface := nc.Spec.Interface
+	if iface == "" {
+		iface = defaultInterface
+	}
+	fileSizeMB := nc.Spec.FileSizeMB
+	if fileSizeMB <= 0 {
+		fileSizeMB = defaultFileSizeMB
+	}
+
+	// tcpdump writes pcap frames to its own stdout instead of rotating files
+	// itself; below, a pcapgo.Reader parses that continuous stream back into
+	// individual packets, which a sinkChunker re-frames into independently
+	// valid pcap files as it dispatches them to the configured Sink.
+	tcpdumpArgs := []string{"-U", "-i", iface, "-w", "-"}
+	if nc.Spec.Filter != "" {
+		tcpdumpArgs = append(tcpdumpArgs, nc.Spec.Filter)
+	}
+
+	args := append([]string{
 		"-t", fmt.Sprintf("%d", pid),
-		"-n", // Check network namespace
+		"-n",
 		"--",
 		"tcpdump",
-		"-Z", "root", // Run as root to ensure write permissions
-		"-i", "any",
-		"-C", "1", // 1MB
-		"-W", limit, // Rotate N files
-		"-w", pcapFile,
+	}, tcpdumpArgs...)
+
+	runCtx := ctx
+	if nc.Spec.Duration != nil {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, nc.Spec.Duration.Duration)
+		defer cancel()
 	}
 
 	slog.Info("Executing nsenter", "args", args)
-	cmd := exec.CommandContext(ctx, "nsenter", args...)
-
-	// Capture stderr for debug
+	cmd := exec.CommandContext(runCtx, "nsenter", args...)
 	cmd.Stderr = os.Stderr
 
-	if err := cmd.Run(); err != nil {
-		// If Context canceled, it's expected
-		if ctx.Err() == context.Canceled {
-			slog.Info("Capture stopped gracefully", "pod", pod.Name)
-		} else {
-			slog.Error("Tcpdump exited with error", "err", err)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cm.failCapture(key, fmt.Sprintf("failed to open tcpdump stdout: %v", err))
+		return
+	}
+
+	if err := cmd.Start(); err != nil {
+		cm.failCapture(key, fmt.Sprintf("failed to start nsenter: %v", err))
+		return
+	}
+	cm.mu.Lock()
+	state.cmd = cmd
+	cm.mu.Unlock()
+
+	fileCount := nc.Spec.FileCount
+	if fileCount <= 0 {
+		fileCount = defaultFileCount
+	}
+
+	baseName := fmt.Sprintf("capture-%s-%s", nc.Namespace, nc.Name)
+	chunker := newSinkChunker(runCtx, cm, sink, key, baseName, "pcap", newPcapChunkWriter, int64(fileSizeMB)*1024*1024, fileCount)
+	if pcapReader, err := pcapgo.NewReader(stdout); err != nil {
+		slog.Error("Failed to parse tcpdump pcap header", "nodecapture", key, "err", err)
+	} else {
+		for {
+			data, ci, err := pcapReader.ReadPacketData()
+			if err != nil {
+				break
+			}
+			if err := chunker.WritePacket(ci, data); err != nil {
+				slog.Error("Failed to write capture chunk to sink", "nodecapture", key, "err", err)
+			}
 		}
 	}
+	if err := chunker.Close(); err != nil {
+		slog.Error("Failed to flush final capture chunk to sink", "nodecapture", key, "err", err)
+	}
+
+	// tcpdump handles SIGINT by flushing its pcap buffers and exiting 0, so
+	// a graceful FinalizeCapture stop ends up here indistinguishable from a
+	// clean, voluntary exit.
+	waitErr := cmd.Wait()
+
+	cm.mu.Lock()
+	stopRequested := state.stopRequested
+	cm.mu.Unlock()
+
+	phase := nodecapturev1alpha1.NodeCapturePhaseCompleted
+	reason := ""
+	if waitErr != nil && !stopRequested && runCtx.Err() == nil {
+		phase = nodecapturev1alpha1.NodeCapturePhaseFailed
+		reason = waitErr.Error()
+		slog.Error("Tcpdump exited with error", "nodecapture", key, "err", waitErr)
+	} else {
+		slog.Info("Capture finished", "nodecapture", key)
+	}
 
-	// Cleanup happens in Stop() usually, but if process dies on its own, ensure map is cleared?
-	// The sync loop will handle state reconciliation, but strictly we should ensure map consistency.
-	// For this task, strict map consistency on self-termination is nice to have.
+	cm.mu.Lock()
+	state.status.Phase = phase
+	state.status.Reason = reason
+	cm.mu.Unlock()
 }
 
-// findPidByContainerID scans /proc to find the PID associated with the container ID (in cgroup).
-// This requires hostPID: true and generated code access to /proc.
-func findPidByContainerID(containerID string) (int, error) {
-	dirs, err := os.ReadDir("/proc")
-	if err != nil {
-		return 0, fmt.Errorf("failed to read /proc: %w", err)
+// chunkPacketWriter is implemented by both pcapgo.Writer (classic pcap) and
+// pcapgo.NgWriter (pcapng), letting sinkChunker stay agnostic to which
+// framing format a backend writes.
+type chunkPacketWriter interface {
+	WritePacket(ci gopacket.CaptureInfo, data []byte) error
+}
+
+// newPcapChunkWriter starts a classic-pcap writer, global header included,
+// over buf. Used by the tcpdump backend.
+func newPcapChunkWriter(buf *bytes.Buffer) (chunkPacketWriter, error) {
+	w := pcapgo.NewWriter(buf)
+	if err := w.WriteFileHeader(maxSnaplen, layers.LinkTypeEthernet); err != nil {
+		return nil, fmt.Errorf("failed to write pcap file header: %w", err)
 	}
+	return w, nil
+}
 
-	for _, d := range dirs {
-		if !d.IsDir() {
-			continue
-		}
-		// Check if name is numeric
-		if _, err := fmt.Sscanf(d.Name(), "%d", new(int)); err != nil {
-			continue
-		}
+// sinkChunker accumulates packets into an in-memory buffer that always
+// starts with a fresh format header, and flushes it to a Sink as a
+// complete, independently-openable capture file once it reaches
+// sizeBytes. This is what makes rotated chunks valid on their own,
+// rather than arbitrary byte slices of one continuous capture stream.
+type sinkChunker struct {
+	cm        *CaptureManager
+	ctx       context.Context
+	sink      Sink
+	key       string
+	baseName  string
+	ext       string
+	newWriter func(*bytes.Buffer) (chunkPacketWriter, error)
+	sizeBytes int64
+	fileCount int32
 
-		cgroupPath := filepath.Join("/proc", d.Name(), "cgroup")
-		f, err := os.Open(cgroupPath)
-		if err != nil {
-			continue
-		}
+	seq    int
+	buf    *bytes.Buffer
+	writer chunkPacketWriter
+}
 
-		// Search for containerID in cgroup file
-		scanner := bufio.NewScanner(f)
-		found := false
-		for scanner.Scan() {
-			if strings.Contains(scanner.Text(), containerID) {
-				found = true
-				break
-			}
+func newSinkChunker(ctx context.Context, cm *CaptureManager, sink Sink, key, baseName, ext string, newWriter func(*bytes.Buffer) (chunkPacketWriter, error), sizeBytes int64, fileCount int32) *sinkChunker {
+	return &sinkChunker{
+		cm:        cm,
+		ctx:       ctx,
+		sink:      sink,
+		key:       key,
+		baseName:  baseName,
+		ext:       ext,
+		newWriter: newWriter,
+		sizeBytes: sizeBytes,
+		fileCount: fileCount,
+	}
+}
+
+// WritePacket appends a packet to the current chunk, rotating to a fresh
+// chunk (with its own header) once the current one reaches the configured
+// size.
+func (c *sinkChunker) WritePacket(ci gopacket.CaptureInfo, data []byte) error {
+	if c.writer == nil {
+		if err := c.startChunk(); err != nil {
+			return err
 		}
-		f.Close()
+	}
+	if err := c.writer.WritePacket(ci, data); err != nil {
+		return err
+	}
+	c.flushWriterBuffer()
+	if int64(c.buf.Len()) >= c.sizeBytes {
+		return c.rotate()
+	}
+	return nil
+}
 
-		if found {
-			var pid int
-			fmt.Sscanf(d.Name(), "%d", &pid)
-			return pid, nil
+// Close flushes whatever chunk is currently in progress to the Sink. It is
+// always safe to call, including when no packet was ever written.
+func (c *sinkChunker) Close() error {
+	if c.writer == nil {
+		return nil
+	}
+	c.flushWriterBuffer()
+	return c.dispatch()
+}
+
+func (c *sinkChunker) startChunk() error {
+	c.buf = &bytes.Buffer{}
+	w, err := c.newWriter(c.buf)
+	if err != nil {
+		return err
+	}
+	c.writer = w
+	return nil
+}
+
+func (c *sinkChunker) rotate() error {
+	if err := c.dispatch(); err != nil {
+		return err
+	}
+	c.writer = nil
+	return nil
+}
+
+// dispatch hands the current chunk's buffered bytes to the Sink as one file
+// and records it in the capture's status.
+func (c *sinkChunker) dispatch() error {
+	if c.buf.Len() == 0 {
+		return nil
+	}
+	filename := fmt.Sprintf("%s-%04d.%s", c.baseName, c.seq, c.ext)
+	n := int64(c.buf.Len())
+	if err := c.sink.Write(c.ctx, c.key, filename, bytes.NewReader(c.buf.Bytes())); err != nil {
+		return fmt.Errorf("writing chunk %s: %w", filename, err)
+	}
+	c.cm.appendChunk(c.key, filename, n, c.fileCount)
+	c.seq++
+	return nil
+}
+
+// flushWriterBuffer pushes any bytes the underlying writer is still holding
+// (pcapgo.NgWriter buffers a block at a time) into buf so size accounting
+// and the eventual dispatch see the whole chunk.
+func (c *sinkChunker) flushWriterBuffer() {
+	if f, ok := c.writer.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			slog.Warn("Failed to flush capture chunk writer", "err", err)
 		}
 	}
-	return 0, fmt.Errorf("pid not found for container %s", containerID)
+}
+
+// appendChunk records a successfully-written chunk in the capture's status.
+// Only the most recent fileCount filenames are kept in Files, mirroring what
+// the old tcpdump -W rotation retained on disk; the Sink itself is
+// responsible for whatever retention its backend offers.
+func (cm *CaptureManager) appendChunk(key, filename string, n int64, fileCount int32) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	state, ok := cm.captures[key]
+	if !ok {
+		return
+	}
+	state.status.Files = append(state.status.Files, filename)
+	state.status.BytesCaptured += n
+	if overflow := len(state.status.Files) - int(fileCount); overflow > 0 {
+		state.status.Files = state.status.Files[overflow:]
+	}
+}
+
+// failCapture marks a capture as Failed before any packets are captured,
+// e.g. because its Sink couldn't be set up or nsenter couldn't be started.
+func (cm *CaptureManager) failCapture(key, reason string) {
+	slog.Error("Capture failed", "nodecapture", key, "reason", reason)
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if state, ok := cm.captures[key]; ok {
+		state.status.Phase = nodecapturev1alpha1.NodeCapturePhaseFailed
+		state.status.Reason = reason
+	}
 }
@@ -0,0 +1,57 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodecapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/nodecapture/v1alpha1"
+)
+
+// alwaysFailResolver counts how many times it's asked to resolve a pid,
+// always failing.
+type alwaysFailResolver struct {
+	calls int
+}
+
+func (r *alwaysFailResolver) Resolve(_ context.Context, _ string) (int, error) {
+	r.calls++
+	return 0, errors.New("cgroup entry not visible yet")
+}
+
+func readyPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "default"},
+		Status: corev1.PodStatus{
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			ContainerStatuses: []corev1.ContainerStatus{{ContainerID: "containerd://abc123"}},
+		},
+	}
+}
+
+// TestSyncCaptureResolvesPidOnceAndReturnsErrorOnFailure verifies that a
+// pid resolution failure is surfaced immediately as an error for the
+// caller to re-enqueue, rather than being retried in-process -- which would
+// block the single worker goroutine from reconciling every other
+// NodeCapture while it slept.
+func TestSyncCaptureResolvesPidOnceAndReturnsErrorOnFailure(t *testing.T) {
+	resolver := &alwaysFailResolver{}
+	cm := &CaptureManager{
+		captures: make(map[string]*captureState),
+		resolver: resolver,
+		backend:  BackendTcpdump,
+	}
+
+	nc := &nodecapturev1alpha1.NodeCapture{ObjectMeta: metav1.ObjectMeta{Name: "nc", Namespace: "default"}}
+
+	_, err := cm.SyncCapture(nc, readyPod())
+	if err == nil {
+		t.Fatal("SyncCapture() error = nil, want an error when pid resolution fails")
+	}
+	if resolver.calls != 1 {
+		t.Errorf("SyncCapture() called Resolve %d times, want exactly 1 (no in-process retry)", resolver.calls)
+	}
+}
@@ -0,0 +1,146 @@
+package capture
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// PidResolver resolves the host PID of a container's init process, given the
+// CRI container ID (without the "containerd://"/"docker://" scheme prefix).
+type PidResolver interface {
+	Resolve(ctx context.Context, containerID string) (int, error)
+}
+
+// CRIPidResolver asks the node's CRI runtime (containerd, CRI-O, ...)
+// directly for a container's PID via ContainerStatus, avoiding a /proc scan
+// and the races that come with it.
+type CRIPidResolver struct {
+	client criapi.RuntimeServiceClient
+}
+
+// NewCRIPidResolver dials the CRI runtime's unix socket, e.g.
+// "unix:///run/containerd/containerd.sock" or "unix:///var/run/crio/crio.sock".
+func NewCRIPidResolver(endpoint string) (*CRIPidResolver, error) {
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial CRI endpoint %s: %w", endpoint, err)
+	}
+	return &CRIPidResolver{client: criapi.NewRuntimeServiceClient(conn)}, nil
+}
+
+func (r *CRIPidResolver) Resolve(ctx context.Context, containerID string) (int, error) {
+	resp, err := r.client.ContainerStatus(ctx, &criapi.ContainerStatusRequest{
+		ContainerId: containerID,
+		Verbose:     true,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("CRI ContainerStatus failed for %s: %w", containerID, err)
+	}
+	pid, ok := resp.Info["pid"]
+	if !ok {
+		return 0, fmt.Errorf("CRI ContainerStatus response for %s did not include a pid", containerID)
+	}
+	var p int
+	if _, err := fmt.Sscanf(pid, "%d", &p); err != nil {
+		return 0, fmt.Errorf("CRI ContainerStatus returned unparseable pid %q: %w", pid, err)
+	}
+	return p, nil
+}
+
+// ProcPidResolver scans /proc/*/cgroup for an entry matching containerID,
+// falling back to this when no CRI socket is reachable. Requires
+// hostPID: true.
+type ProcPidResolver struct{}
+
+func (ProcPidResolver) Resolve(_ context.Context, containerID string) (int, error) {
+	return findPidByContainerID(containerID)
+}
+
+// findPidByContainerID scans /proc to find the PID associated with the
+// container ID. It matches both cgroupfs-style paths (where the raw
+// container ID is the last path component, e.g. ".../pod<uid>/<id>", in
+// cgroup v1 and in the single-line cgroup v2 unified entry "0::/...") and
+// the systemd cgroup driver's scope naming convention.
+func findPidByContainerID(containerID string) (int, error) {
+	dirs, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read /proc: %w", err)
+	}
+
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		if _, err := fmt.Sscanf(d.Name(), "%d", new(int)); err != nil {
+			continue
+		}
+
+		cgroupPath := filepath.Join("/proc", d.Name(), "cgroup")
+		f, err := os.Open(cgroupPath)
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(f)
+		found := false
+		for scanner.Scan() {
+			if cgroupLineMatchesContainer(scanner.Text(), containerID) {
+				found = true
+				break
+			}
+		}
+		f.Close()
+
+		if found {
+			var pid int
+			fmt.Sscanf(d.Name(), "%d", &pid)
+			return pid, nil
+		}
+	}
+	return 0, fmt.Errorf("pid not found for container %s", containerID)
+}
+
+// cgroupLineMatchesContainer checks a single /proc/<pid>/cgroup line against
+// containerID. A cgroup v1 line looks like
+// "1:name=systemd:/kubepods.slice/.../cri-containerd-<id>.scope"; a cgroup v2
+// unified line looks like "0::/kubepods.slice/.../cri-containerd-<id>.scope".
+// Either way the line is matched by a substring search for containerID, so
+// a raw cgroupfs ID and systemd's "cri-containerd-<id>.scope",
+// "crio-<id>.scope" and "docker-<id>.scope" unit names all match, whatever
+// systemd's escaping of the slice path components that come before them.
+func cgroupLineMatchesContainer(line, containerID string) bool {
+	if strings.Contains(line, containerID) {
+		return true
+	}
+	for _, prefix := range []string{"cri-containerd-", "crio-", "docker-"} {
+		if strings.Contains(line, prefix+containerID+".scope") {
+			return true
+		}
+	}
+	return false
+}
+
+// CompositeResolver tries the CRI runtime first and falls back to scanning
+// /proc when no CRI socket is reachable (e.g. a non-standard runtime socket
+// path, or the runtime not supporting the pid info field).
+type CompositeResolver struct {
+	Primary  PidResolver
+	Fallback PidResolver
+}
+
+func (r *CompositeResolver) Resolve(ctx context.Context, containerID string) (int, error) {
+	if r.Primary != nil {
+		if pid, err := r.Primary.Resolve(ctx, containerID); err == nil {
+			return pid, nil
+		}
+	}
+	return r.Fallback.Resolve(ctx, containerID)
+}
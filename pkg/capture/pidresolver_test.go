@@ -0,0 +1,82 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCgroupLineMatchesContainer(t *testing.T) {
+	const containerID = "abc123def456"
+
+	tests := []struct {
+		name string
+		line string
+		want bool
+	}{
+		{
+			name: "cgroup v1 raw container id",
+			line: "1:name=systemd:/kubepods.slice/kubepods-pod.slice/" + containerID,
+			want: true,
+		},
+		{
+			name: "cgroup v2 unified raw container id",
+			line: "0::/kubepods.slice/kubepods-pod.slice/" + containerID,
+			want: true,
+		},
+		{
+			name: "systemd containerd scope naming",
+			line: "0::/kubepods.slice/kubepods-pod.slice/cri-containerd-" + containerID + ".scope",
+			want: true,
+		},
+		{
+			name: "systemd crio scope naming",
+			line: "0::/kubepods.slice/kubepods-pod.slice/crio-" + containerID + ".scope",
+			want: true,
+		},
+		{
+			name: "unrelated container",
+			line: "0::/kubepods.slice/kubepods-pod.slice/cri-containerd-someothercontainer.scope",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cgroupLineMatchesContainer(tt.line, containerID); got != tt.want {
+				t.Errorf("cgroupLineMatchesContainer(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+// flakyResolver fails the first failCount calls to Resolve, then succeeds.
+type flakyResolver struct {
+	failCount int
+	calls     int
+}
+
+func (r *flakyResolver) Resolve(_ context.Context, _ string) (int, error) {
+	r.calls++
+	if r.calls <= r.failCount {
+		return 0, errors.New("cgroup entry not yet visible")
+	}
+	return 4242, nil
+}
+
+func TestCompositeResolverFallsBackToSecondary(t *testing.T) {
+	primary := &flakyResolver{failCount: 1000}
+	fallback := &flakyResolver{failCount: 0}
+	resolver := &CompositeResolver{Primary: primary, Fallback: fallback}
+
+	pid, err := resolver.Resolve(context.Background(), "some-container")
+	if err != nil {
+		t.Fatalf("CompositeResolver.Resolve() error = %v", err)
+	}
+	if pid != 4242 {
+		t.Errorf("CompositeResolver.Resolve() pid = %d, want 4242", pid)
+	}
+	if fallback.calls != 1 {
+		t.Errorf("CompositeResolver.Resolve() fallback called %d times, want 1", fallback.calls)
+	}
+}
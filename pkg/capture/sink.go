@@ -0,0 +1,263 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log/slog"
+	"log/syslog"
+	"os"
+	"path/filepath"
+
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	capturesinkv1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/capturesink/v1alpha1"
+	packetcapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/packetcapture/v1alpha1"
+)
+
+// Sink delivers one rotated pcap chunk for a capture to its configured
+// destination. filename is a name only (no directory component); key is the
+// "<namespace>/<name>" of the NodeCapture running the capture.
+type Sink interface {
+	Write(ctx context.Context, key, filename string, data io.Reader) error
+	Close() error
+}
+
+// SinkForDestination builds the Sink a PacketCapture's Destination asks for.
+// Exactly one destination field is expected to be set; LocalDir is assumed
+// when none is.
+func SinkForDestination(dest packetcapturev1alpha1.PacketCaptureDestination) (Sink, error) {
+	switch {
+	case dest.S3 != nil:
+		return NewS3Sink(*dest.S3)
+	case dest.Kafka != nil:
+		return NewKafkaSink(*dest.Kafka), nil
+	case dest.GRPC != nil:
+		return NewGRPCSink(*dest.GRPC)
+	case dest.Syslog != nil:
+		return NewSyslogSink(*dest.Syslog)
+	case dest.LocalDir != nil:
+		return NewLocalDirSink(dest.LocalDir.Path), nil
+	default:
+		slog.Warn("No capture destination set, defaulting to local directory", "dir", CaptureDir)
+		return NewLocalDirSink(CaptureDir), nil
+	}
+}
+
+// LocalDirSink writes each chunk as its own file in a directory on the
+// capturing Node — the original, pre-Sink behavior.
+type LocalDirSink struct {
+	dir string
+}
+
+func NewLocalDirSink(dir string) *LocalDirSink {
+	return &LocalDirSink{dir: dir}
+}
+
+func (s *LocalDirSink) Write(_ context.Context, _, filename string, data io.Reader) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create capture directory %s: %w", s.dir, err)
+	}
+	f, err := os.Create(filepath.Join(s.dir, filename))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, data)
+	return err
+}
+
+func (s *LocalDirSink) Close() error { return nil }
+
+// s3Uploader is the subset of the AWS S3 client Sink needs, kept narrow so
+// tests can fake it without pulling in the real SDK.
+type s3Uploader interface {
+	PutObject(ctx context.Context, bucket, key string, body io.Reader) error
+}
+
+// S3Sink uploads each rotated pcap chunk as an object, keyed by the
+// NodeCapture running the capture and the chunk filename.
+type S3Sink struct {
+	client s3Uploader
+	bucket string
+	prefix string
+}
+
+func NewS3Sink(dest packetcapturev1alpha1.S3Destination) (*S3Sink, error) {
+	client, err := newAWSS3Client(dest.Region, dest.CredentialsName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build S3 client: %w", err)
+	}
+	return &S3Sink{client: client, bucket: dest.Bucket, prefix: dest.Prefix}, nil
+}
+
+func (s *S3Sink) Write(ctx context.Context, key, filename string, data io.Reader) error {
+	objectKey := filepath.Join(s.prefix, key, filename)
+	return s.client.PutObject(ctx, s.bucket, objectKey, data)
+}
+
+func (s *S3Sink) Close() error { return nil }
+
+// SyslogSink forwards a one-line event describing each chunk -- not the
+// pcap bytes themselves, which syslog's message-oriented framing isn't
+// suited to carry -- to a remote syslog endpoint, per
+// PacketCaptureDestination.Syslog's "forwards capture events" contract.
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+func NewSyslogSink(dest packetcapturev1alpha1.SyslogDestination) (*SyslogSink, error) {
+	protocol := dest.Protocol
+	if protocol == "" {
+		protocol = "udp"
+	}
+	w, err := syslog.Dial(protocol, dest.Address, syslog.LOG_INFO|syslog.LOG_DAEMON, "antrea-packetcapture")
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial syslog endpoint %s: %w", dest.Address, err)
+	}
+	return &SyslogSink{writer: w}, nil
+}
+
+func (s *SyslogSink) Write(_ context.Context, key, filename string, data io.Reader) error {
+	n, err := io.Copy(io.Discard, data)
+	if err != nil {
+		return fmt.Errorf("failed to read chunk for syslog event: %w", err)
+	}
+	return s.writer.Info(fmt.Sprintf("packetcapture=%s chunk=%s bytes=%d", key, filename, n))
+}
+
+func (s *SyslogSink) Close() error {
+	return s.writer.Close()
+}
+
+// KafkaSink publishes each chunk as a single Kafka message, keyed by the
+// NodeCapture running the capture so a consumer can reassemble that
+// capture's chunks in order.
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaSink(dest packetcapturev1alpha1.KafkaDestination) *KafkaSink {
+	return &KafkaSink{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(dest.Brokers...),
+			Topic:    dest.Topic,
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+func (s *KafkaSink) Write(ctx context.Context, key, filename string, data io.Reader) error {
+	payload, err := io.ReadAll(data)
+	if err != nil {
+		return fmt.Errorf("failed to buffer chunk for kafka: %w", err)
+	}
+	return s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(key),
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "filename", Value: []byte(filename)},
+		},
+	})
+}
+
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
+
+// GRPCSink streams each chunk to a central collector over gRPC, for
+// deployments that want capture evidence off the node immediately rather
+// than polled from object storage.
+type GRPCSink struct {
+	conn   *grpc.ClientConn
+	client capturesinkv1alpha1.CaptureSinkClient
+	seq    int32
+}
+
+// grpcTLSMountDir is the base directory under which a GRPCDestination's
+// named TLSSecretName is expected to be mounted onto the DaemonSet, each
+// under its own subdirectory -- the same "Secret mounted onto the DaemonSet"
+// convention newAWSS3Client relies on for its credentials Secret.
+const grpcTLSMountDir = "/etc/antrea-packetcapture/grpc-tls"
+
+func NewGRPCSink(dest packetcapturev1alpha1.GRPCDestination) (*GRPCSink, error) {
+	creds := insecure.NewCredentials()
+	if dest.TLSSecretName != "" {
+		c, err := loadGRPCClientTLSCredentials(dest.TLSSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS credentials for grpc destination %s: %w", dest.Address, err)
+		}
+		creds = c
+	}
+	conn, err := grpc.NewClient(dest.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial capture collector %s: %w", dest.Address, err)
+	}
+	return &GRPCSink{conn: conn, client: capturesinkv1alpha1.NewCaptureSinkClient(conn)}, nil
+}
+
+// loadGRPCClientTLSCredentials builds client TLS credentials from
+// secretName's mount point under grpcTLSMountDir: ca.crt verifies the
+// collector's server certificate, and tls.crt/tls.key -- if present -- are
+// presented back for mutual TLS.
+func loadGRPCClientTLSCredentials(secretName string) (credentials.TransportCredentials, error) {
+	dir := filepath.Join(grpcTLSMountDir, secretName)
+
+	caPEM, err := os.ReadFile(filepath.Join(dir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no valid certificates found in %s/ca.crt", dir)
+	}
+	cfg := &tls.Config{RootCAs: pool}
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	if _, err := os.Stat(certFile); err == nil {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client keypair: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+func (s *GRPCSink) Write(ctx context.Context, key, filename string, data io.Reader) error {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, data); err != nil {
+		return fmt.Errorf("failed to buffer chunk for grpc: %w", err)
+	}
+	s.seq++
+	ack, err := s.client.SendChunk(ctx, &capturesinkv1alpha1.PcapChunk{
+		Key:      key,
+		Filename: filename,
+		Sequence: s.seq,
+		Data:     buf.Bytes(),
+	})
+	if err != nil {
+		return err
+	}
+	if !ack.Accepted {
+		return fmt.Errorf("collector rejected chunk %s for %s", filename, key)
+	}
+	return nil
+}
+
+func (s *GRPCSink) Close() error {
+	return s.conn.Close()
+}
+
+func logSinkCloseErr(key string, err error) {
+	if err != nil {
+		slog.Warn("Failed to close capture sink", "packetcapture", key, "err", err)
+	}
+}
@@ -0,0 +1,79 @@
+package capture
+
+import (
+	"testing"
+
+	packetcapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/packetcapture/v1alpha1"
+)
+
+func TestSinkForDestinationRouting(t *testing.T) {
+	tests := []struct {
+		name string
+		dest packetcapturev1alpha1.PacketCaptureDestination
+		want string
+	}{
+		{
+			name: "local dir",
+			dest: packetcapturev1alpha1.PacketCaptureDestination{
+				LocalDir: &packetcapturev1alpha1.LocalDirDestination{Path: "/tmp/captures"},
+			},
+			want: "*capture.LocalDirSink",
+		},
+		{
+			name: "kafka",
+			dest: packetcapturev1alpha1.PacketCaptureDestination{
+				Kafka: &packetcapturev1alpha1.KafkaDestination{Brokers: []string{"broker:9092"}, Topic: "captures"},
+			},
+			want: "*capture.KafkaSink",
+		},
+		{
+			name: "grpc",
+			dest: packetcapturev1alpha1.PacketCaptureDestination{
+				GRPC: &packetcapturev1alpha1.GRPCDestination{Address: "collector:9443"},
+			},
+			want: "*capture.GRPCSink",
+		},
+		{
+			name: "syslog",
+			dest: packetcapturev1alpha1.PacketCaptureDestination{
+				Syslog: &packetcapturev1alpha1.SyslogDestination{Address: "127.0.0.1:514", Protocol: "udp"},
+			},
+			want: "*capture.SyslogSink",
+		},
+		{
+			name: "unset falls back to local dir",
+			dest: packetcapturev1alpha1.PacketCaptureDestination{},
+			want: "*capture.LocalDirSink",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, err := SinkForDestination(tt.dest)
+			if err != nil {
+				t.Fatalf("SinkForDestination() error = %v", err)
+			}
+			defer sink.Close()
+			if got := sinkTypeName(sink); got != tt.want {
+				t.Errorf("SinkForDestination() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func sinkTypeName(s Sink) string {
+	switch s.(type) {
+	case *LocalDirSink:
+		return "*capture.LocalDirSink"
+	case *S3Sink:
+		return "*capture.S3Sink"
+	case *KafkaSink:
+		return "*capture.KafkaSink"
+	case *GRPCSink:
+		return "*capture.GRPCSink"
+	case *SyslogSink:
+		return "*capture.SyslogSink"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,496 @@
+// Package centralcontroller implements the cluster-wide counterpart to
+// pkg/controller's node-local agent. It runs as a single, leader-elected
+// replica (see cmd/manager), resolves each PacketCapture's target selector
+// to the Pod(s) it currently matches anywhere in the cluster, dispatches one
+// NodeCapture per matched Pod for the node-local agent on that Pod's Node to
+// pick up, and aggregates their statuses back onto the parent PacketCapture.
+package centralcontroller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log/slog"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	nodecapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/nodecapture/v1alpha1"
+	packetcapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/packetcapture/v1alpha1"
+	clientset "github.com/user/antrea-lfx-task/pkg/client/clientset/versioned"
+)
+
+// Controller resolves PacketCapture target selectors against cluster-wide
+// Pods and dispatches/aggregates per-Pod NodeCapture objects.
+type Controller struct {
+	client      clientset.Interface
+	podIndexer  cache.Indexer
+	pcIndexer   cache.Indexer
+	ncIndexer   cache.Indexer
+	podInformer cache.SharedIndexInformer
+	pcInformer  cache.SharedIndexInformer
+	ncInformer  cache.SharedIndexInformer
+	queue       workqueue.TypedRateLimitingInterface[string]
+}
+
+func NewController(
+	client clientset.Interface,
+	podInformer cache.SharedIndexInformer,
+	pcInformer cache.SharedIndexInformer,
+	ncInformer cache.SharedIndexInformer,
+) *Controller {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+
+	c := &Controller{
+		client:      client,
+		podIndexer:  podInformer.GetIndexer(),
+		pcIndexer:   pcInformer.GetIndexer(),
+		ncIndexer:   ncInformer.GetIndexer(),
+		podInformer: podInformer,
+		pcInformer:  pcInformer,
+		ncInformer:  ncInformer,
+		queue:       queue,
+	}
+
+	pcInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueuePacketCapture(obj) },
+		UpdateFunc: func(_, newObj interface{}) { c.enqueuePacketCapture(newObj) },
+		DeleteFunc: func(obj interface{}) { c.enqueuePacketCapture(obj) },
+	})
+
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueuePacketCapturesForPod(obj) },
+		UpdateFunc: func(_, newObj interface{}) { c.enqueuePacketCapturesForPod(newObj) },
+		DeleteFunc: func(obj interface{}) { c.enqueuePacketCapturesForPod(obj) },
+	})
+
+	ncInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueuePacketCaptureOwning(obj) },
+		UpdateFunc: func(_, newObj interface{}) { c.enqueuePacketCaptureOwning(newObj) },
+		DeleteFunc: func(obj interface{}) { c.enqueuePacketCaptureOwning(obj) },
+	})
+
+	return c
+}
+
+func (c *Controller) enqueuePacketCapture(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// enqueuePacketCapturesForPod re-synchronizes every PacketCapture whose
+// target selector could match the Pod, mirroring how the Kubernetes job
+// controller maps Pod events back to their owning Job.
+func (c *Controller) enqueuePacketCapturesForPod(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			pod, ok = tombstone.Obj.(*corev1.Pod)
+			if !ok {
+				return
+			}
+		} else {
+			return
+		}
+	}
+
+	for _, obj := range c.pcIndexer.List() {
+		pc := obj.(*packetcapturev1alpha1.PacketCapture)
+		if targetMatchesPod(&pc.Spec.Target, pod) {
+			c.queue.Add(pc.Namespace + "/" + pc.Name)
+		}
+	}
+}
+
+// enqueuePacketCaptureOwning re-synchronizes the PacketCapture that owns a
+// NodeCapture whenever that NodeCapture's status changes, so aggregation
+// picks up the new per-Pod result promptly instead of waiting for the next
+// resync.
+func (c *Controller) enqueuePacketCaptureOwning(obj interface{}) {
+	nc, ok := obj.(*nodecapturev1alpha1.NodeCapture)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			nc, ok = tombstone.Obj.(*nodecapturev1alpha1.NodeCapture)
+			if !ok {
+				return
+			}
+		} else {
+			return
+		}
+	}
+	if owner := nc.Labels[nodecapturev1alpha1.OwnerLabel]; owner != "" {
+		c.queue.Add(nc.Namespace + "/" + owner)
+	}
+}
+
+func targetMatchesPod(target *packetcapturev1alpha1.PacketCaptureTargetSelector, pod *corev1.Pod) bool {
+	if pod.Namespace != target.Namespace {
+		return false
+	}
+	if target.PodName != "" {
+		return target.PodName == pod.Name
+	}
+	if target.PodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(target.PodSelector)
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("invalid podSelector: %w", err))
+			return false
+		}
+		return selector.Matches(labels.Set(pod.Labels))
+	}
+	return false
+}
+
+func (c *Controller) Run(stopCh chan struct{}) {
+	defer c.queue.ShutDown()
+	slog.Info("Starting central PacketCapture Controller")
+
+	go c.podInformer.Run(stopCh)
+	go c.pcInformer.Run(stopCh)
+	go c.ncInformer.Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, c.podInformer.HasSynced, c.pcInformer.HasSynced, c.ncInformer.HasSynced) {
+		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+		return
+	}
+
+	go wait.Until(c.runWorker, time.Second, stopCh)
+
+	<-stopCh
+	slog.Info("Stopping central PacketCapture Controller")
+}
+
+func (c *Controller) runWorker() {
+	for c.processNextItem() {
+	}
+}
+
+func (c *Controller) processNextItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncHandler(key)
+	c.handleErr(err, key)
+	return true
+}
+
+func (c *Controller) syncHandler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	obj, exists, err := c.pcIndexer.GetByKey(key)
+	if err != nil {
+		slog.Error("Fetching PacketCapture with key failed", "key", key, "err", err)
+		return err
+	}
+	if !exists {
+		// Kubernetes garbage collection cleans up owned NodeCaptures once the
+		// owner is gone; nothing left for us to do.
+		return nil
+	}
+
+	pc := obj.(*packetcapturev1alpha1.PacketCapture)
+
+	if pc.DeletionTimestamp != nil {
+		return c.syncDeletingCapture(namespace, name, pc, key)
+	}
+
+	if !hasFinalizer(pc, packetcapturev1alpha1.CaptureFinalizer) {
+		if err := c.addFinalizer(namespace, pc); err != nil {
+			return err
+		}
+	}
+
+	pods := c.resolveTargetPods(pc)
+	desired := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		desired[nodeCaptureName(pc, pod)] = true
+		if err := c.ensureNodeCapture(pc, pod); err != nil {
+			return err
+		}
+	}
+	if err := c.gcStaleNodeCaptures(pc, desired); err != nil {
+		return err
+	}
+
+	status := c.aggregateStatus(pc)
+	return c.updateStatus(namespace, name, pc, status)
+}
+
+// gcStaleNodeCaptures deletes NodeCaptures this PacketCapture previously
+// dispatched for a Pod that no longer matches its target selector -- e.g.
+// the Pod was replaced by a Deployment rolling restart, or a podSelector
+// stopped matching it. Without this, a Pod falling out of the selector
+// leaves its NodeCapture (and the capture still running on that Node)
+// orphaned forever, since Kubernetes garbage collection via OwnerReference
+// only fires once the parent PacketCapture itself is deleted.
+func (c *Controller) gcStaleNodeCaptures(pc *packetcapturev1alpha1.PacketCapture, desired map[string]bool) error {
+	for _, nc := range c.ownedNodeCaptures(pc) {
+		if desired[nc.Name] || nc.DeletionTimestamp != nil {
+			continue
+		}
+		if err := c.client.NodecaptureV1alpha1().NodeCaptures(nc.Namespace).Delete(context.TODO(), nc.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncDeletingCapture propagates deletion to every NodeCapture this
+// PacketCapture owns and waits for them to actually disappear (their own
+// finalizer drains the in-flight capture first) before dropping its own
+// finalizer, so `kubectl delete packetcapture` blocks until every Node has
+// gracefully stopped capturing.
+func (c *Controller) syncDeletingCapture(namespace, name string, pc *packetcapturev1alpha1.PacketCapture, key string) error {
+	if !hasFinalizer(pc, packetcapturev1alpha1.CaptureFinalizer) {
+		return nil
+	}
+
+	owned := c.ownedNodeCaptures(pc)
+	if len(owned) == 0 {
+		return c.removeFinalizer(namespace, pc)
+	}
+
+	for _, nc := range owned {
+		if nc.DeletionTimestamp != nil {
+			continue
+		}
+		if err := c.client.NodecaptureV1alpha1().NodeCaptures(nc.Namespace).Delete(context.TODO(), nc.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	// Not done yet; come back once the NodeCaptures finish deleting rather
+	// than busy-looping the workqueue.
+	c.queue.AddRateLimited(key)
+	return nil
+}
+
+// nodeCaptureName derives the deterministic name under which pc dispatches a
+// NodeCapture for pod. Joining pc.Name and pod.Name directly isn't
+// collision-free within a namespace (e.g. PacketCapture "a-b" targeting Pod
+// "c" collides with PacketCapture "a" targeting Pod "b-c"), so, following the
+// convention Kubernetes' own Job controller uses for the Pods it dispatches,
+// the Pod's UID is hashed into the name instead of its (mutable, reusable)
+// name.
+func nodeCaptureName(pc *packetcapturev1alpha1.PacketCapture, pod *corev1.Pod) string {
+	h := fnv.New32a()
+	io.WriteString(h, string(pod.UID))
+	return fmt.Sprintf("%s-%x", pc.Name, h.Sum32())
+}
+
+// ensureNodeCapture creates or updates the NodeCapture dispatched for pod,
+// keyed deterministically off the PacketCapture name and the Pod's UID (see
+// nodeCaptureName) so repeated syncs are idempotent.
+func (c *Controller) ensureNodeCapture(pc *packetcapturev1alpha1.PacketCapture, pod *corev1.Pod) error {
+	name := nodeCaptureName(pc, pod)
+	desiredSpec := nodecapturev1alpha1.NodeCaptureSpec{
+		NodeName:     pod.Spec.NodeName,
+		PodNamespace: pod.Namespace,
+		PodName:      pod.Name,
+		Interface:    pc.Spec.Interface,
+		Filter:       pc.Spec.Filter,
+		FileCount:    pc.Spec.FileCount,
+		FileSizeMB:   pc.Spec.FileSizeMB,
+		Duration:     pc.Spec.Duration,
+		Destination:  pc.Spec.Destination,
+	}
+
+	if obj, exists, err := c.ncIndexer.GetByKey(pc.Namespace + "/" + name); err != nil {
+		return err
+	} else if exists {
+		existing := obj.(*nodecapturev1alpha1.NodeCapture)
+		if apiequality.Semantic.DeepEqual(existing.Spec, desiredSpec) &&
+			existing.Labels[nodecapturev1alpha1.NodeNameLabel] == pod.Spec.NodeName {
+			return nil
+		}
+		updated := existing.DeepCopy()
+		updated.Spec = desiredSpec
+		if updated.Labels == nil {
+			updated.Labels = make(map[string]string, 1)
+		}
+		updated.Labels[nodecapturev1alpha1.NodeNameLabel] = pod.Spec.NodeName
+		_, err := c.client.NodecaptureV1alpha1().NodeCaptures(pc.Namespace).Update(context.TODO(), updated, metav1.UpdateOptions{})
+		return err
+	}
+
+	nc := &nodecapturev1alpha1.NodeCapture{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: pc.Namespace,
+			Labels: map[string]string{
+				nodecapturev1alpha1.NodeNameLabel: pod.Spec.NodeName,
+				nodecapturev1alpha1.OwnerLabel:     pc.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(pc, packetcapturev1alpha1.SchemeGroupVersion.WithKind("PacketCapture")),
+			},
+		},
+		Spec: desiredSpec,
+	}
+	_, err := c.client.NodecaptureV1alpha1().NodeCaptures(pc.Namespace).Create(context.TODO(), nc, metav1.CreateOptions{})
+	if err != nil && errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// resolveTargetPods finds every Pod a PacketCapture's target selector
+// currently matches, cluster-wide.
+func (c *Controller) resolveTargetPods(pc *packetcapturev1alpha1.PacketCapture) []*corev1.Pod {
+	target := pc.Spec.Target
+	if target.PodName != "" {
+		obj, exists, err := c.podIndexer.GetByKey(target.Namespace + "/" + target.PodName)
+		if err != nil || !exists {
+			return nil
+		}
+		return []*corev1.Pod{obj.(*corev1.Pod)}
+	}
+
+	var pods []*corev1.Pod
+	for _, obj := range c.podIndexer.List() {
+		pod := obj.(*corev1.Pod)
+		if targetMatchesPod(&target, pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// ownedNodeCaptures returns every NodeCapture labeled as belonging to pc.
+func (c *Controller) ownedNodeCaptures(pc *packetcapturev1alpha1.PacketCapture) []*nodecapturev1alpha1.NodeCapture {
+	var owned []*nodecapturev1alpha1.NodeCapture
+	for _, obj := range c.ncIndexer.List() {
+		nc := obj.(*nodecapturev1alpha1.NodeCapture)
+		if nc.Namespace == pc.Namespace && nc.Labels[nodecapturev1alpha1.OwnerLabel] == pc.Name {
+			owned = append(owned, nc)
+		}
+	}
+	return owned
+}
+
+// aggregateStatus rolls every NodeCapture owned by pc up into a single
+// PacketCaptureStatus: Failed if any Node failed, else Running if any Node
+// is still running, else Completed once every Node has finished, else
+// Pending while nothing has been dispatched yet or some Node has yet to
+// start.
+func (c *Controller) aggregateStatus(pc *packetcapturev1alpha1.PacketCapture) *packetcapturev1alpha1.PacketCaptureStatus {
+	owned := c.ownedNodeCaptures(pc)
+	if len(owned) == 0 {
+		return &packetcapturev1alpha1.PacketCaptureStatus{Phase: packetcapturev1alpha1.PacketCapturePhasePending}
+	}
+
+	status := &packetcapturev1alpha1.PacketCaptureStatus{Phase: packetcapturev1alpha1.PacketCapturePhaseCompleted}
+	var failedNodes []string
+	anyRunning, anyPending := false, false
+	for _, nc := range owned {
+		status.BytesCaptured += nc.Status.BytesCaptured
+		status.Files = append(status.Files, nc.Status.Files...)
+		if status.StartTime == nil || (nc.Status.StartTime != nil && nc.Status.StartTime.Before(status.StartTime)) {
+			status.StartTime = nc.Status.StartTime
+		}
+		switch nc.Status.Phase {
+		case nodecapturev1alpha1.NodeCapturePhaseFailed:
+			failedNodes = append(failedNodes, fmt.Sprintf("%s: %s", nc.Spec.NodeName, nc.Status.Reason))
+		case nodecapturev1alpha1.NodeCapturePhaseRunning:
+			anyRunning = true
+		case nodecapturev1alpha1.NodeCapturePhaseCompleted:
+		default:
+			// Pending, or not yet reported by the node-local agent.
+			anyPending = true
+		}
+	}
+
+	switch {
+	case len(failedNodes) > 0:
+		status.Phase = packetcapturev1alpha1.PacketCapturePhaseFailed
+		status.Reason = fmt.Sprintf("%d/%d nodes failed: %v", len(failedNodes), len(owned), failedNodes)
+	case anyRunning:
+		status.Phase = packetcapturev1alpha1.PacketCapturePhaseRunning
+	case anyPending:
+		status.Phase = packetcapturev1alpha1.PacketCapturePhasePending
+	}
+	return status
+}
+
+func hasFinalizer(pc *packetcapturev1alpha1.PacketCapture, finalizer string) bool {
+	for _, f := range pc.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Controller) addFinalizer(namespace string, pc *packetcapturev1alpha1.PacketCapture) error {
+	updated := pc.DeepCopy()
+	updated.Finalizers = append(updated.Finalizers, packetcapturev1alpha1.CaptureFinalizer)
+	_, err := c.client.PacketcaptureV1alpha1().PacketCaptures(namespace).Update(context.TODO(), updated, metav1.UpdateOptions{})
+	if err != nil {
+		slog.Error("Failed to add capture finalizer", "namespace", namespace, "name", pc.Name, "err", err)
+	}
+	return err
+}
+
+func (c *Controller) removeFinalizer(namespace string, pc *packetcapturev1alpha1.PacketCapture) error {
+	updated := pc.DeepCopy()
+	kept := updated.Finalizers[:0]
+	for _, f := range updated.Finalizers {
+		if f != packetcapturev1alpha1.CaptureFinalizer {
+			kept = append(kept, f)
+		}
+	}
+	updated.Finalizers = kept
+	_, err := c.client.PacketcaptureV1alpha1().PacketCaptures(namespace).Update(context.TODO(), updated, metav1.UpdateOptions{})
+	if err != nil {
+		slog.Error("Failed to remove capture finalizer", "namespace", namespace, "name", pc.Name, "err", err)
+	}
+	return err
+}
+
+func (c *Controller) updateStatus(namespace, name string, pc *packetcapturev1alpha1.PacketCapture, status *packetcapturev1alpha1.PacketCaptureStatus) error {
+	if apiequality.Semantic.DeepEqual(&pc.Status, status) {
+		return nil
+	}
+	updated := pc.DeepCopy()
+	updated.Status = *status
+	_, err := c.client.PacketcaptureV1alpha1().PacketCaptures(namespace).UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+	if err != nil {
+		slog.Error("Failed to update PacketCapture status", "namespace", namespace, "name", name, "err", err)
+	}
+	return err
+}
+
+func (c *Controller) handleErr(err error, key string) {
+	if err == nil {
+		c.queue.Forget(key)
+		return
+	}
+
+	if c.queue.NumRequeues(key) < 5 {
+		slog.Warn("Error syncing PacketCapture, requeuing", "key", key, "err", err)
+		c.queue.AddRateLimited(key)
+		return
+	}
+
+	c.queue.Forget(key)
+	runtime.HandleError(err)
+}
@@ -0,0 +1,133 @@
+package centralcontroller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	nodecapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/nodecapture/v1alpha1"
+	packetcapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/packetcapture/v1alpha1"
+)
+
+func newTestController(ncs ...*nodecapturev1alpha1.NodeCapture) *Controller {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, nc := range ncs {
+		indexer.Add(nc)
+	}
+	return &Controller{ncIndexer: indexer}
+}
+
+func nodeCapture(name string, phase nodecapturev1alpha1.NodeCapturePhase, reason string) *nodecapturev1alpha1.NodeCapture {
+	return &nodecapturev1alpha1.NodeCapture{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{nodecapturev1alpha1.OwnerLabel: "pc"},
+		},
+		Status: nodecapturev1alpha1.NodeCaptureStatus{Phase: phase, Reason: reason},
+	}
+}
+
+func TestAggregateStatus(t *testing.T) {
+	pc := &packetcapturev1alpha1.PacketCapture{ObjectMeta: metav1.ObjectMeta{Name: "pc", Namespace: "default"}}
+
+	tests := []struct {
+		name string
+		ncs  []*nodecapturev1alpha1.NodeCapture
+		want packetcapturev1alpha1.PacketCapturePhase
+	}{
+		{name: "no nodecaptures yet", ncs: nil, want: packetcapturev1alpha1.PacketCapturePhasePending},
+		{
+			name: "all completed",
+			ncs: []*nodecapturev1alpha1.NodeCapture{
+				nodeCapture("pc-a", nodecapturev1alpha1.NodeCapturePhaseCompleted, ""),
+				nodeCapture("pc-b", nodecapturev1alpha1.NodeCapturePhaseCompleted, ""),
+			},
+			want: packetcapturev1alpha1.PacketCapturePhaseCompleted,
+		},
+		{
+			name: "one still running",
+			ncs: []*nodecapturev1alpha1.NodeCapture{
+				nodeCapture("pc-a", nodecapturev1alpha1.NodeCapturePhaseCompleted, ""),
+				nodeCapture("pc-b", nodecapturev1alpha1.NodeCapturePhaseRunning, ""),
+			},
+			want: packetcapturev1alpha1.PacketCapturePhaseRunning,
+		},
+		{
+			name: "one failed wins over running",
+			ncs: []*nodecapturev1alpha1.NodeCapture{
+				nodeCapture("pc-a", nodecapturev1alpha1.NodeCapturePhaseRunning, ""),
+				nodeCapture("pc-b", nodecapturev1alpha1.NodeCapturePhaseFailed, "netns gone"),
+			},
+			want: packetcapturev1alpha1.PacketCapturePhaseFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestController(tt.ncs...)
+			status := c.aggregateStatus(pc)
+			if status.Phase != tt.want {
+				t.Errorf("aggregateStatus() phase = %s, want %s", status.Phase, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetMatchesPod(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-0",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "web"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		target packetcapturev1alpha1.PacketCaptureTargetSelector
+		want   bool
+	}{
+		{
+			name:   "matching pod name",
+			target: packetcapturev1alpha1.PacketCaptureTargetSelector{Namespace: "default", PodName: "web-0"},
+			want:   true,
+		},
+		{
+			name:   "non-matching pod name",
+			target: packetcapturev1alpha1.PacketCaptureTargetSelector{Namespace: "default", PodName: "web-1"},
+			want:   false,
+		},
+		{
+			name:   "wrong namespace",
+			target: packetcapturev1alpha1.PacketCaptureTargetSelector{Namespace: "other", PodName: "web-0"},
+			want:   false,
+		},
+		{
+			name: "matching pod selector",
+			target: packetcapturev1alpha1.PacketCaptureTargetSelector{
+				Namespace:   "default",
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+			want: true,
+		},
+		{
+			name: "non-matching pod selector",
+			target: packetcapturev1alpha1.PacketCaptureTargetSelector{
+				Namespace:   "default",
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := targetMatchesPod(&tt.target, pod); got != tt.want {
+				t.Errorf("targetMatchesPod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,42 +1,113 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
+	nodecapturev1alpha1 "github.com/user/antrea-lfx-task/pkg/apis/nodecapture/v1alpha1"
 	"github.com/user/antrea-lfx-task/pkg/capture"
+	clientset "github.com/user/antrea-lfx-task/pkg/client/clientset/versioned"
 )
 
+// Controller is the node-local agent: it reconciles the NodeCapture objects
+// the central controller has dispatched to this Node, starting and stopping
+// captures through a CaptureManager and reporting progress back onto each
+// NodeCapture's status subresource. Its NodeCapture informer is expected to
+// already be scoped to this Node (see nodecapturev1alpha1.NodeNameLabel).
 type Controller struct {
-	indexer  cache.Indexer
-	queue    workqueue.TypedRateLimitingInterface[string]
-	informer cache.SharedIndexInformer
-	manager  *capture.CaptureManager
+	client      clientset.Interface
+	podIndexer  cache.Indexer
+	ncIndexer   cache.Indexer
+	podInformer cache.SharedIndexInformer
+	ncInformer  cache.SharedIndexInformer
+	queue       workqueue.TypedRateLimitingInterface[string]
+	manager     *capture.CaptureManager
 }
 
-func NewController(queue workqueue.TypedRateLimitingInterface[string], indexer cache.Indexer, informer cache.SharedIndexInformer) *Controller {
-	return &Controller{
-		indexer:  indexer,
-		queue:    queue,
-		informer: informer,
-		manager:  capture.NewCaptureManager(),
+func NewController(
+	client clientset.Interface,
+	podInformer cache.SharedIndexInformer,
+	ncInformer cache.SharedIndexInformer,
+	captureBackend string,
+) *Controller {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+
+	c := &Controller{
+		client:      client,
+		podIndexer:  podInformer.GetIndexer(),
+		ncIndexer:   ncInformer.GetIndexer(),
+		podInformer: podInformer,
+		ncInformer:  ncInformer,
+		queue:       queue,
+		manager:     capture.NewCaptureManager(captureBackend),
+	}
+
+	ncInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueNodeCapture(obj) },
+		UpdateFunc: func(_, newObj interface{}) { c.enqueueNodeCapture(newObj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueNodeCapture(obj) },
+	})
+
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueNodeCapturesForPod(obj) },
+		UpdateFunc: func(_, newObj interface{}) { c.enqueueNodeCapturesForPod(newObj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueNodeCapturesForPod(obj) },
+	})
+
+	return c
+}
+
+func (c *Controller) enqueueNodeCapture(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// enqueueNodeCapturesForPod re-synchronizes every NodeCapture that targets
+// the Pod, mirroring how the Kubernetes job controller maps Pod events back
+// to their owning Job.
+func (c *Controller) enqueueNodeCapturesForPod(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			pod, ok = tombstone.Obj.(*corev1.Pod)
+			if !ok {
+				return
+			}
+		} else {
+			return
+		}
+	}
+
+	for _, obj := range c.ncIndexer.List() {
+		nc := obj.(*nodecapturev1alpha1.NodeCapture)
+		if nc.Spec.PodNamespace == pod.Namespace && nc.Spec.PodName == pod.Name {
+			c.queue.Add(nc.Namespace + "/" + nc.Name)
+		}
 	}
 }
 
 func (c *Controller) Run(stopCh chan struct{}) {
 	defer c.queue.ShutDown()
-	slog.Info("Starting PacketCapture Controller")
+	slog.Info("Starting NodeCapture Controller")
 
-	go c.informer.Run(stopCh)
+	go c.podInformer.Run(stopCh)
+	go c.ncInformer.Run(stopCh)
 
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
+	if !cache.WaitForCacheSync(stopCh, c.podInformer.HasSynced, c.ncInformer.HasSynced) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
 		return
 	}
@@ -44,7 +115,7 @@ func (c *Controller) Run(stopCh chan struct{}) {
 	go wait.Until(c.runWorker, time.Second, stopCh)
 
 	<-stopCh
-	slog.Info("Stopping PacketCapture Controller")
+	slog.Info("Stopping NodeCapture Controller")
 }
 
 func (c *Controller) runWorker() {
@@ -65,37 +136,161 @@ func (c *Controller) processNextItem() bool {
 }
 
 func (c *Controller) syncHandler(key string) error {
-	obj, exists, err := c.indexer.GetByKey(key)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
-		slog.Error("Fetching object with key failed", "key", key, "err", err)
 		return err
 	}
 
+	obj, exists, err := c.ncIndexer.GetByKey(key)
+	if err != nil {
+		slog.Error("Fetching NodeCapture with key failed", "key", key, "err", err)
+		return err
+	}
 	if !exists {
-		// If Pod is deleted, we might need to cleanup.
-		// However, our CaptureManager handles cleanup if it tracked the pod by UID.
-		// BUT the Manager only knows about Active captures by UID.
-		// If the pod is gone from the API server, we can't get its UID easily from just the key (namespace/name).
-		// Wait, 'key' is not UID.
-		// If exists is false, we can't call manager.SyncCapture(pod).
-		// But in a real scenario, Deletion is handled by DeleteFunc which has the 'obj' (last known state).
-		// We can add a fallback cleanup if needed, but Manager tracks by UID.
-		// Strictly, if Pod is deleted, key comes here. But we don't have the UID unless we cache it.
-		// Simplification: relying on DeleteFunc adding to queue? No, queue only has string key.
-		// K8s pattern: When 'exists' is false, it means deleted.
-		// Our Manager cleans up when it sees DeletionTimestamp or internal map vs reality.
-		// Issue: If we don't pass the Pod, Manager doesn't know WHO to stop.
-		// Solution: The CaptureManager should probably be robust or we track mapping in Controller.
-		// Let's assume DeleteFunc + UpdateFunc ensures Manager sees the deletion state *before* it vanishes,
-		// OR we accept that restarting the DaemonSet kills orphans (simpler).
-		// For this task, handling Update with DeletionTimestamp is usually enough.
-		// The deletion event might arrive after the object is gone.
-		// We'll rely on the fact that before full deletion, we get an update with DeletionTimestamp.
+		// The NodeCapture is gone; make sure any capture we were running for
+		// it is torn down too.
+		c.manager.StopCaptureByKey(key)
+		return nil
+	}
+
+	nc := obj.(*nodecapturev1alpha1.NodeCapture)
+
+	if nc.DeletionTimestamp != nil {
+		return c.syncDeletingCapture(namespace, name, nc, key)
+	}
+
+	if !hasFinalizer(nc, nodecapturev1alpha1.Finalizer) {
+		if err := c.addFinalizer(namespace, nc); err != nil {
+			return err
+		}
+	}
+
+	pod, err := c.resolveTargetPod(nc)
+	if err != nil {
+		return err
+	}
+
+	if pod == nil {
+		return c.syncMissingPod(namespace, name, nc, key)
+	}
+
+	status, err := c.manager.SyncCapture(nc, pod)
+	if err != nil {
+		return err
+	}
+	if status == nil {
 		return nil
 	}
+	return c.updateStatus(namespace, name, nc, status)
+}
 
-	pod := obj.(*corev1.Pod)
-	return c.manager.SyncCapture(pod)
+// syncMissingPod gracefully stops a capture whose target Pod has disappeared
+// out from under it -- e.g. a Deployment rolling restart under a
+// PacketCapture podSelector -- the same way syncDeletingCapture stops one
+// whose NodeCapture object is itself being deleted. It leaves the
+// NodeCapture's own finalizer alone; the object is still live, only its
+// target is gone.
+func (c *Controller) syncMissingPod(namespace, name string, nc *nodecapturev1alpha1.NodeCapture, key string) error {
+	done, status := c.manager.FinalizeCapture(nc)
+	if !done {
+		// Cleanup is still flushing buffers; come back shortly rather than
+		// busy-looping the workqueue.
+		c.queue.AddRateLimited(key)
+		return nil
+	}
+	if status == nil {
+		// Nothing was running yet (still Pending when the Pod vanished).
+		status = &nodecapturev1alpha1.NodeCaptureStatus{
+			Phase:  nodecapturev1alpha1.NodeCapturePhaseFailed,
+			Reason: "target pod no longer exists",
+		}
+	}
+	return c.updateStatus(namespace, name, nc, status)
+}
+
+// syncDeletingCapture drives a NodeCapture through graceful shutdown: stop
+// the capture so it flushes whatever buffers it holds, wait for that to
+// finish, persist the final status, and only then drop the finalizer so the
+// object can actually be removed.
+func (c *Controller) syncDeletingCapture(namespace, name string, nc *nodecapturev1alpha1.NodeCapture, key string) error {
+	if !hasFinalizer(nc, nodecapturev1alpha1.Finalizer) {
+		return nil
+	}
+
+	done, status := c.manager.FinalizeCapture(nc)
+	if !done {
+		// Capture cleanup is still in flight; come back shortly rather than
+		// busy-looping the workqueue.
+		c.queue.AddRateLimited(key)
+		return nil
+	}
+
+	if status != nil {
+		if err := c.updateStatus(namespace, name, nc, status); err != nil {
+			return err
+		}
+	}
+
+	return c.removeFinalizer(namespace, nc)
+}
+
+func hasFinalizer(nc *nodecapturev1alpha1.NodeCapture, finalizer string) bool {
+	for _, f := range nc.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Controller) addFinalizer(namespace string, nc *nodecapturev1alpha1.NodeCapture) error {
+	updated := nc.DeepCopy()
+	updated.Finalizers = append(updated.Finalizers, nodecapturev1alpha1.Finalizer)
+	_, err := c.client.NodecaptureV1alpha1().NodeCaptures(namespace).Update(context.TODO(), updated, metav1.UpdateOptions{})
+	if err != nil {
+		slog.Error("Failed to add capture finalizer", "namespace", namespace, "name", nc.Name, "err", err)
+	}
+	return err
+}
+
+func (c *Controller) removeFinalizer(namespace string, nc *nodecapturev1alpha1.NodeCapture) error {
+	updated := nc.DeepCopy()
+	kept := updated.Finalizers[:0]
+	for _, f := range updated.Finalizers {
+		if f != nodecapturev1alpha1.Finalizer {
+			kept = append(kept, f)
+		}
+	}
+	updated.Finalizers = kept
+	_, err := c.client.NodecaptureV1alpha1().NodeCaptures(namespace).Update(context.TODO(), updated, metav1.UpdateOptions{})
+	if err != nil {
+		slog.Error("Failed to remove capture finalizer", "namespace", namespace, "name", nc.Name, "err", err)
+	}
+	return err
+}
+
+// resolveTargetPod looks up the single Pod a NodeCapture names. Unlike the
+// PacketCapture selector it replaces, a NodeCapture is always dispatched
+// against one already-resolved Pod, so no selector matching happens here.
+func (c *Controller) resolveTargetPod(nc *nodecapturev1alpha1.NodeCapture) (*corev1.Pod, error) {
+	obj, exists, err := c.podIndexer.GetByKey(nc.Spec.PodNamespace + "/" + nc.Spec.PodName)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return obj.(*corev1.Pod), nil
+}
+
+func (c *Controller) updateStatus(namespace, name string, nc *nodecapturev1alpha1.NodeCapture, status *nodecapturev1alpha1.NodeCaptureStatus) error {
+	if apiequality.Semantic.DeepEqual(&nc.Status, status) {
+		return nil
+	}
+	updated := nc.DeepCopy()
+	updated.Status = *status
+	_, err := c.client.NodecaptureV1alpha1().NodeCaptures(namespace).UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+	if err != nil {
+		slog.Error("Failed to update NodeCapture status", "namespace", namespace, "name", name, "err", err)
+	}
+	return err
 }
 
 func (c *Controller) handleErr(err error, key string) {
@@ -105,7 +300,7 @@ func (c *Controller) handleErr(err error, key string) {
 	}
 
 	if c.queue.NumRequeues(key) < 5 {
-		slog.Warn("Error syncing pod, requeuing", "key", key, "err", err)
+		slog.Warn("Error syncing NodeCapture, requeuing", "key", key, "err", err)
 		c.queue.AddRateLimited(key)
 		return
 	}